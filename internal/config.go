@@ -31,6 +31,8 @@ const (
 	_        Mode = iota
 	ScanFill Mode = iota
 	Append
+	ParallelScanFill
+	Verify
 )
 
 func (mode Mode) Name() string {
@@ -39,6 +41,10 @@ func (mode Mode) Name() string {
 		return "Scan and Fill"
 	case Append:
 		return "Append"
+	case ParallelScanFill:
+		return "Parallel Scan and Fill"
+	case Verify:
+		return "Verify"
 	}
 	return "none"
 }
@@ -59,6 +65,29 @@ type StellarCoreConfig struct {
 	CaptiveCoreTomlPath   string   `toml:"captive_core_toml_path"`
 	CheckpointFrequency   uint32   `toml:"checkpoint_frequency"`
 	StoragePath           string   `toml:"storage_path"`
+
+	// MinimumRequiredProtocolVersion overrides the network's required protocol version, for pre-vote testing.
+	MinimumRequiredProtocolVersion uint32 `toml:"minimum_required_protocol_version"`
+
+	// TailPollInterval is how often a caught-up Append tail re-checks for new ledgers (e.g. "6s").
+	TailPollInterval string `toml:"tail_poll_interval"`
+
+	// TailMaxFlushInterval bounds how long a partial tailed batch can sit unflushed (e.g. "5m").
+	TailMaxFlushInterval string `toml:"tail_max_flush_interval"`
+}
+
+// ParallelSettings configures how a bounded ledger range is sharded across
+// concurrent captive-core instances in ParallelScanFill mode.
+type ParallelSettings struct {
+	WorkerCount   uint32 `toml:"worker_count"`
+	JobLedgerSize uint32 `toml:"job_ledger_size"`
+}
+
+// LedgerRange is an inclusive sub-range of ledgers handed to a single
+// parallel worker.
+type LedgerRange struct {
+	StartLedger uint32
+	EndLedger   uint32
 }
 
 type DataStoreConfig struct {
@@ -72,27 +101,21 @@ type DataStoreSchema struct {
 	FilesPerPartition uint32 `toml:"files_per_partition"`
 }
 
-// Validate checks if the datastore configuration is valid
+// Validate checks if the datastore configuration is valid, delegating the
+// backend-specific parameter checks to whatever DataStoreFactory was
+// registered for c.Type via RegisterDataStore.
 func (c *DataStoreConfig) Validate() error {
 	if c.Type == "" {
 		return errors.New("datastore type is required")
 	}
 
-	switch c.Type {
-	case "GCS":
-		if _, ok := c.Params["destination_bucket_path"]; !ok {
-			return errors.New("destination_bucket_path is required for GCS")
-		}
-	case "S3":
-		if _, ok := c.Params["bucket_name"]; !ok {
-			return errors.New("bucket_name is required for S3")
-		}
-	case "FS":
-		if _, ok := c.Params["base_path"]; !ok {
-			return errors.New("base_path is required for FS")
-		}
-	default:
-		return fmt.Errorf("unsupported datastore type: %s", c.Type)
+	factory, err := lookupDataStore(c.Type)
+	if err != nil {
+		return err
+	}
+
+	if err := factory.Validate(c.Params); err != nil {
+		return err
 	}
 
 	if c.Schema.LedgersPerFile == 0 {
@@ -106,19 +129,21 @@ func (c *DataStoreConfig) Validate() error {
 	return nil
 }
 
-// GetDataStore creates a new datastore instance based on the configuration
+// GetDataStore creates a new datastore instance based on the configuration,
+// using the DataStoreFactory registered for c.Type.
 func (c *DataStoreConfig) GetDataStore(ctx context.Context) (datastore.DataStore, error) {
 	if err := c.Validate(); err != nil {
 		return nil, err
 	}
 
-	return datastore.NewDataStore(ctx, datastore.DataStoreConfig{
-		Type:   c.Type,
-		Params: c.Params,
-		Schema: datastore.DataStoreSchema{
-			LedgersPerFile:    c.Schema.LedgersPerFile,
-			FilesPerPartition: c.Schema.FilesPerPartition,
-		},
+	factory, err := lookupDataStore(c.Type)
+	if err != nil {
+		return nil, err
+	}
+
+	return factory.New(ctx, c.Params, datastore.DataStoreSchema{
+		LedgersPerFile:    c.Schema.LedgersPerFile,
+		FilesPerPartition: c.Schema.FilesPerPartition,
 	})
 }
 
@@ -127,6 +152,8 @@ type Config struct {
 
 	DataStoreConfig   datastore.DataStoreConfig `toml:"datastore_config"`
 	StellarCoreConfig StellarCoreConfig         `toml:"stellar_core_config"`
+	ParallelSettings  ParallelSettings          `toml:"parallel"`
+	VerifySettings    VerifySettings            `toml:"verify"`
 	UserAgent         string                    `toml:"user_agent"`
 
 	StartLedger uint32
@@ -134,8 +161,10 @@ type Config struct {
 	Mode        Mode
 
 	CoreVersion               string
+	CoreMaxProtocolVersion    uint32
 	SerializedCaptiveCoreToml []byte
 	CoreBuildVersionFn        ledgerbackend.CoreBuildVersionFunc
+	CoreProtocolVersionFn     CoreProtocolVersionFunc
 }
 
 // This will generate the config based on settings
@@ -143,7 +172,7 @@ type Config struct {
 // settings              - requested settings
 //
 // return                - *Config or an error if any range validation failed.
-func NewConfig(settings RuntimeSettings, getCoreVersionFn ledgerbackend.CoreBuildVersionFunc) (*Config, error) {
+func NewConfig(settings RuntimeSettings, getCoreVersionFn ledgerbackend.CoreBuildVersionFunc, getCoreProtocolVersionFn CoreProtocolVersionFunc) (*Config, error) {
 	config := &Config{}
 
 	config.StartLedger = uint32(settings.StartLedger)
@@ -153,6 +182,10 @@ func NewConfig(settings RuntimeSettings, getCoreVersionFn ledgerbackend.CoreBuil
 	if getCoreVersionFn != nil {
 		config.CoreBuildVersionFn = getCoreVersionFn
 	}
+	config.CoreProtocolVersionFn = CoreProtocolVersion
+	if getCoreProtocolVersionFn != nil {
+		config.CoreProtocolVersionFn = getCoreProtocolVersionFn
+	}
 
 	logger.Infof("Requested export mode of %v with start=%d, end=%d", settings.Mode.Name(), config.StartLedger, config.EndLedger)
 
@@ -180,7 +213,7 @@ func (config *Config) ValidateAndSetLedgerRange(ctx context.Context, archive his
 		return errors.New("invalid start value, must be greater than one.")
 	}
 
-	if config.Mode == ScanFill && config.EndLedger == 0 {
+	if (config.Mode == ScanFill || config.Mode == ParallelScanFill || config.Mode == Verify) && config.EndLedger == 0 {
 		return errors.New("invalid end value, unbounded mode not supported, end must be greater than start.")
 	}
 
@@ -188,6 +221,15 @@ func (config *Config) ValidateAndSetLedgerRange(ctx context.Context, archive his
 		return errors.New("invalid end value, must be greater than start")
 	}
 
+	if config.Mode == ParallelScanFill {
+		if config.ParallelSettings.WorkerCount == 0 {
+			return errors.New("parallel.worker_count must be greater than 0")
+		}
+		if config.ParallelSettings.JobLedgerSize == 0 {
+			return errors.New("parallel.job_ledger_size must be greater than 0")
+		}
+	}
+
 	latestNetworkLedger, err := archive.GetLatestLedgerSequence()
 	latestNetworkLedger = latestNetworkLedger + (archive.GetCheckpointManager().GetCheckpointFrequency() * 2)
 
@@ -206,6 +248,10 @@ func (config *Config) ValidateAndSetLedgerRange(ctx context.Context, archive his
 			config.EndLedger, latestNetworkLedger)
 	}
 
+	if err := config.checkProtocolCompatibility(archive); err != nil {
+		return err
+	}
+
 	config.adjustLedgerRange()
 	return nil
 }
@@ -234,8 +280,14 @@ func (config *Config) GenerateCaptiveCoreConfig(coreBinFromPath string) (ledgerb
 		config.StellarCoreConfig.StellarCoreBinaryPath = coreBinFromPath
 	}
 
-	if err = config.setCoreVersionInfo(); err != nil {
-		return ledgerbackend.CaptiveCoreConfig{}, fmt.Errorf("failed to set stellar-core version info: %w", err)
+	// CoreVersion/CoreMaxProtocolVersion are typically already populated by
+	// ValidateAndSetLedgerRange's protocol compatibility check; avoid
+	// shelling out to stellar-core again for every caller (e.g. once per
+	// ParallelScanFill worker) when that's already been done.
+	if config.CoreVersion == "" {
+		if err = config.setCoreVersionInfo(); err != nil {
+			return ledgerbackend.CaptiveCoreConfig{}, fmt.Errorf("failed to set stellar-core version info: %w", err)
+		}
 	}
 
 	params := ledgerbackend.CaptiveCoreTomlParams{
@@ -272,9 +324,55 @@ func (c *Config) setCoreVersionInfo() (err error) {
 		return fmt.Errorf("failed to set stellar-core version: %w", err)
 	}
 	logger.Infof("stellar-core version: %s", c.CoreVersion)
+
+	c.CoreMaxProtocolVersion, err = c.CoreProtocolVersionFn(c.StellarCoreConfig.StellarCoreBinaryPath)
+	if err != nil {
+		return fmt.Errorf("failed to set stellar-core max supported protocol version: %w", err)
+	}
+	logger.Infof("stellar-core max supported protocol version: %d", c.CoreMaxProtocolVersion)
 	return nil
 }
 
+// checkProtocolCompatibility fails fast when the configured stellar-core
+// binary does not support the protocol version required by the network, so
+// operators don't discover mid-run that their pinned core image can't
+// ingest a recently upgraded network.
+func (config *Config) checkProtocolCompatibility(archive historyarchive.ArchiveInterface) error {
+	if config.CoreVersion == "" {
+		if err := config.setCoreVersionInfo(); err != nil {
+			return err
+		}
+	}
+
+	has, err := archive.GetRootHAS()
+	if err != nil {
+		return errors.Wrap(err, "failed to retrieve the latest history archive state")
+	}
+
+	networkProtocol, err := networkProtocolVersion(archive, has.CurrentLedger)
+	if err != nil {
+		return errors.Wrap(err, "failed to determine the network's current protocol version")
+	}
+	logger.Infof("Network protocol version was detected as %d", networkProtocol)
+
+	requiredProtocol := networkProtocol
+	if config.StellarCoreConfig.MinimumRequiredProtocolVersion > 0 {
+		requiredProtocol = config.StellarCoreConfig.MinimumRequiredProtocolVersion
+	}
+
+	return evaluateProtocolCompatibility(config.CoreMaxProtocolVersion, requiredProtocol)
+}
+
+// networkProtocolVersion fetches the ledger header for the given ledger from
+// the history archive and returns the protocol version it closed under.
+func networkProtocolVersion(archive historyarchive.ArchiveInterface, ledger uint32) (uint32, error) {
+	headerEntry, err := archive.GetLedgerHeader(ledger)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to fetch ledger header from history archive")
+	}
+	return uint32(headerEntry.Header.LedgerVersion), nil
+}
+
 func (config *Config) processToml(tomlPath string) error {
 	// Load config TOML file
 	cfg, err := toml.LoadFile(tomlPath)
@@ -353,6 +451,58 @@ func (config *Config) adjustLedgerRange() {
 	logger.Infof("Computed effective export boundary ledger range: start=%d, end=%d", config.StartLedger, config.EndLedger)
 }
 
+// ComputeJobRanges splits the configured [StartLedger, EndLedger] range into
+// contiguous sub-ranges no larger than ParallelSettings.JobLedgerSize rounded
+// up to a multiple of LedgersPerFile, each aligned on LedgersPerFile
+// boundaries so that no two workers ever write into the same datastore file.
+func (config *Config) ComputeJobRanges() ([]LedgerRange, error) {
+	if config.ParallelSettings.JobLedgerSize == 0 {
+		return nil, errors.New("parallel.job_ledger_size must be greater than 0")
+	}
+
+	schema := config.DataStoreConfig.Schema
+	jobSize := roundUpToMultiple(config.ParallelSettings.JobLedgerSize, schema.LedgersPerFile)
+
+	// Split from the file boundary at or below StartLedger so every job
+	// starts on a file boundary, then clamp the first job back to StartLedger.
+	var ranges []LedgerRange
+	for _, r := range splitRangeIntoJobs(schema.GetSequenceNumberStartBoundary(config.StartLedger), config.EndLedger, jobSize) {
+		ranges = append(ranges, LedgerRange{
+			StartLedger: ordered.Max(r.StartLedger, config.StartLedger),
+			EndLedger:   schema.GetSequenceNumberEndBoundary(r.EndLedger),
+		})
+	}
+	return ranges, nil
+}
+
+// roundUpToMultiple rounds value up to the nearest multiple of step (or
+// returns value unchanged if step is 0), so a configured job size never
+// straddles a file boundary.
+func roundUpToMultiple(value, step uint32) uint32 {
+	if step == 0 {
+		return value
+	}
+	if remainder := value % step; remainder != 0 {
+		return value + (step - remainder)
+	}
+	return value
+}
+
+// splitRangeIntoJobs splits the inclusive [start, end] range into
+// contiguous, inclusive sub-ranges no larger than jobSize, with the final
+// sub-range truncated to end.
+func splitRangeIntoJobs(start, end, jobSize uint32) []LedgerRange {
+	var ranges []LedgerRange
+	for s := start; s <= end; s += jobSize {
+		e := s + jobSize - 1
+		if e > end {
+			e = end
+		}
+		ranges = append(ranges, LedgerRange{StartLedger: s, EndLedger: e})
+	}
+	return ranges
+}
+
 // ToParams converts the config to a map of parameters
 func (c *DataStoreConfig) ToParams() map[string]string {
 	return c.Params
@@ -0,0 +1,56 @@
+package galexie
+
+import (
+	"fmt"
+	"os/exec"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/stellar/go/support/errors"
+)
+
+// CoreProtocolVersionFunc resolves the maximum ledger protocol version
+// supported by the given stellar-core binary.
+type CoreProtocolVersionFunc func(binaryPath string) (uint32, error)
+
+var coreVersionProtocolPattern = regexp.MustCompile(`(?i)ledger protocol version:\s*(\d+)`)
+
+// CoreProtocolVersion shells out to `stellar-core --version` and parses the
+// maximum ledger protocol version the binary advertises support for.
+func CoreProtocolVersion(binaryPath string) (uint32, error) {
+	out, err := exec.Command(binaryPath, "--version").CombinedOutput()
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to run stellar-core --version")
+	}
+	return parseMaxSupportedProtocol(string(out))
+}
+
+// evaluateProtocolCompatibility compares the stellar-core binary's max
+// supported protocol against the protocol version required (derived from
+// the network's current protocol, or the minimum_required_protocol_version
+// override) and returns a descriptive error when core is behind.
+func evaluateProtocolCompatibility(coreMaxProtocol, requiredProtocol uint32) error {
+	if coreMaxProtocol < requiredProtocol {
+		return fmt.Errorf(
+			"configured stellar-core binary only supports protocol %d, but protocol %d is required; "+
+				"upgrade the stellar-core binary before exporting", coreMaxProtocol, requiredProtocol)
+	}
+	return nil
+}
+
+// parseMaxSupportedProtocol extracts the "ledger protocol version" line
+// emitted by `stellar-core --version`.
+func parseMaxSupportedProtocol(versionOutput string) (uint32, error) {
+	matches := coreVersionProtocolPattern.FindStringSubmatch(versionOutput)
+	if matches == nil {
+		return 0, fmt.Errorf("could not find ledger protocol version in stellar-core --version output: %q",
+			strings.TrimSpace(versionOutput))
+	}
+
+	protocol, err := strconv.ParseUint(matches[1], 10, 32)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to parse ledger protocol version")
+	}
+	return uint32(protocol), nil
+}
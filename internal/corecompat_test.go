@@ -0,0 +1,71 @@
+package galexie
+
+import "testing"
+
+func TestParseMaxSupportedProtocol(t *testing.T) {
+	cases := []struct {
+		name    string
+		output  string
+		want    uint32
+		wantErr bool
+	}{
+		{
+			name:   "standard stellar-core output",
+			output: "stellar-core 20.2.0 (abcdef)\nrust version: 1.74.0\nledger protocol version: 20\n",
+			want:   20,
+		},
+		{
+			name:   "case insensitive label",
+			output: "LEDGER PROTOCOL VERSION: 21",
+			want:   21,
+		},
+		{
+			name:    "missing protocol line",
+			output:  "stellar-core 20.2.0 (abcdef)\n",
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := parseMaxSupportedProtocol(tc.output)
+			if tc.wantErr {
+				if err == nil {
+					t.Fatalf("expected an error, got protocol %d", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tc.want {
+				t.Fatalf("got protocol %d, want %d", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestEvaluateProtocolCompatibility(t *testing.T) {
+	cases := []struct {
+		name     string
+		coreMax  uint32
+		required uint32
+		wantErr  bool
+	}{
+		{name: "core newer than network", coreMax: 21, required: 20, wantErr: false},
+		{name: "core matches network", coreMax: 20, required: 20, wantErr: false},
+		{name: "core older than network", coreMax: 19, required: 20, wantErr: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := evaluateProtocolCompatibility(tc.coreMax, tc.required)
+			if tc.wantErr && err == nil {
+				t.Fatalf("expected an error for core=%d required=%d", tc.coreMax, tc.required)
+			}
+			if !tc.wantErr && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	}
+}
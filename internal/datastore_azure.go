@@ -0,0 +1,152 @@
+package galexie
+
+import (
+	"bytes"
+	"context"
+	stderrors "errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob"
+	"github.com/stellar/go/support/errors"
+	datastore "github.com/withObsrvr/stellar-datastore"
+)
+
+// ifNoneMatchAny is the Azure convention for "only proceed if no blob
+// currently exists at this path", used to make PutFileIfNotExists an
+// atomic conditional write instead of a racy check-then-act.
+const ifNoneMatchAny = "*"
+
+func init() {
+	RegisterDataStore("AzureBlob", DataStoreFactory{
+		Validate: validateAzureBlobParams,
+		New:      newAzureBlobDataStore,
+	})
+}
+
+func validateAzureBlobParams(params map[string]string) error {
+	if _, ok := params["container_url"]; !ok {
+		return errors.New("container_url is required for AzureBlob")
+	}
+	if _, ok := params["sas_token"]; !ok {
+		return errors.New("sas_token is required for AzureBlob")
+	}
+	return nil
+}
+
+// azureBlobDataStore implements datastore.DataStore against an Azure Blob
+// Storage container, authenticating with a caller-supplied SAS token so
+// galexie never needs to hold a storage account key.
+type azureBlobDataStore struct {
+	client *azblob.ContainerClient
+}
+
+func newAzureBlobDataStore(ctx context.Context, params map[string]string, schema datastore.DataStoreSchema) (datastore.DataStore, error) {
+	if err := validateAzureBlobParams(params); err != nil {
+		return nil, err
+	}
+
+	containerURL := fmt.Sprintf("%s?%s", params["container_url"], params["sas_token"])
+	client, err := azblob.NewContainerClientWithNoCredential(containerURL, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create Azure Blob container client")
+	}
+
+	return &azureBlobDataStore{client: client}, nil
+}
+
+func (d *azureBlobDataStore) GetFile(ctx context.Context, path string) (io.ReadCloser, error) {
+	resp, err := d.client.NewBlobClient(path).Download(ctx, nil)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to download %s from Azure Blob", path)
+	}
+	return resp.Body(nil), nil
+}
+
+func (d *azureBlobDataStore) PutFile(ctx context.Context, path string, in io.WriterTo) error {
+	var buf bytes.Buffer
+	if _, err := in.WriteTo(&buf); err != nil {
+		return errors.Wrapf(err, "failed to buffer %s before upload", path)
+	}
+	_, err := d.client.NewBlockBlobClient(path).UploadBuffer(ctx, buf.Bytes(), nil)
+	if err != nil {
+		return errors.Wrapf(err, "failed to upload %s to Azure Blob", path)
+	}
+	return nil
+}
+
+// PutFileIfNotExists uploads path only if no blob currently exists there,
+// using an If-None-Match: * conditional header so the check-and-write is
+// atomic at the Azure service rather than racing a separate Exists call.
+func (d *azureBlobDataStore) PutFileIfNotExists(ctx context.Context, path string, in io.WriterTo) (bool, error) {
+	var buf bytes.Buffer
+	if _, err := in.WriteTo(&buf); err != nil {
+		return false, errors.Wrapf(err, "failed to buffer %s before upload", path)
+	}
+
+	etag := ifNoneMatchAny
+	_, err := d.client.NewBlockBlobClient(path).UploadBuffer(ctx, buf.Bytes(), &azblob.UploadBufferOptions{
+		AccessConditions: &azblob.AccessConditions{
+			ModifiedAccessConditions: &azblob.ModifiedAccessConditions{
+				IfNoneMatch: &etag,
+			},
+		},
+	})
+	if err == nil {
+		return true, nil
+	}
+
+	var respErr *azcore.ResponseError
+	if stderrors.As(err, &respErr) && respErr.StatusCode == http.StatusConflict {
+		return false, nil
+	}
+	return false, errors.Wrapf(err, "failed to conditionally upload %s to Azure Blob", path)
+}
+
+// Exists reports whether a blob is present at path, distinguishing a
+// genuine "not found" response from other errors (auth failures, timeouts,
+// transient network errors) so that callers like the gap-scan in
+// RunParallelScanFill don't mistake a transient error for a missing file.
+func (d *azureBlobDataStore) Exists(ctx context.Context, path string) (bool, error) {
+	_, err := d.client.NewBlobClient(path).GetProperties(ctx, nil)
+	if err == nil {
+		return true, nil
+	}
+
+	var respErr *azcore.ResponseError
+	if stderrors.As(err, &respErr) && respErr.StatusCode == http.StatusNotFound {
+		return false, nil
+	}
+	return false, errors.Wrapf(err, "failed to check existence of %s in Azure Blob", path)
+}
+
+func (d *azureBlobDataStore) Size(ctx context.Context, path string) (int64, error) {
+	props, err := d.client.NewBlobClient(path).GetProperties(ctx, nil)
+	if err != nil {
+		return 0, errors.Wrapf(err, "failed to stat %s in Azure Blob", path)
+	}
+	if props.ContentLength == nil {
+		return 0, nil
+	}
+	return *props.ContentLength, nil
+}
+
+func (d *azureBlobDataStore) ListFilePaths(ctx context.Context, prefix string) ([]string, error) {
+	var paths []string
+	pager := d.client.ListBlobsFlat(&azblob.ContainerListBlobsFlatOptions{Prefix: &prefix})
+	for pager.NextPage(ctx) {
+		for _, blob := range pager.PageResponse().Segment.BlobItems {
+			paths = append(paths, *blob.Name)
+		}
+	}
+	if err := pager.Err(); err != nil {
+		return nil, errors.Wrapf(err, "failed to list blobs under %s", prefix)
+	}
+	return paths, nil
+}
+
+func (d *azureBlobDataStore) Close() error {
+	return nil
+}
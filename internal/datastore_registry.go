@@ -0,0 +1,92 @@
+package galexie
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/stellar/go/support/errors"
+	datastore "github.com/withObsrvr/stellar-datastore"
+)
+
+// DataStoreFactory constructs a datastore.DataStore for a registered
+// backend type and validates the `params` map supplied via a datastore
+// config's TOML `params` table before a datastore is built from them.
+type DataStoreFactory struct {
+	New      func(ctx context.Context, params map[string]string, schema datastore.DataStoreSchema) (datastore.DataStore, error)
+	Validate func(params map[string]string) error
+}
+
+var (
+	dataStoreRegistryMu sync.RWMutex
+	dataStoreRegistry   = map[string]DataStoreFactory{}
+)
+
+// RegisterDataStore makes a datastore backend available under the given
+// type name for use as DataStoreConfig.Type. A backend registers itself by
+// calling this from an init() function before NewConfig is invoked, as the
+// GCS, S3, FS and AzureBlob backends in this package do. This package lives
+// under internal/, so only code inside this module can call it.
+func RegisterDataStore(name string, factory DataStoreFactory) {
+	dataStoreRegistryMu.Lock()
+	defer dataStoreRegistryMu.Unlock()
+	if _, exists := dataStoreRegistry[name]; exists {
+		panic(fmt.Sprintf("galexie: RegisterDataStore called twice for datastore type %q", name))
+	}
+	dataStoreRegistry[name] = factory
+}
+
+func lookupDataStore(name string) (DataStoreFactory, error) {
+	dataStoreRegistryMu.RLock()
+	defer dataStoreRegistryMu.RUnlock()
+	factory, ok := dataStoreRegistry[name]
+	if !ok {
+		return DataStoreFactory{}, fmt.Errorf("unsupported datastore type: %s", name)
+	}
+	return factory, nil
+}
+
+func init() {
+	RegisterDataStore("GCS", DataStoreFactory{
+		Validate: func(params map[string]string) error {
+			if _, ok := params["destination_bucket_path"]; !ok {
+				return errors.New("destination_bucket_path is required for GCS")
+			}
+			return nil
+		},
+		New: nativeDataStoreFactory("GCS"),
+	})
+
+	RegisterDataStore("S3", DataStoreFactory{
+		Validate: func(params map[string]string) error {
+			if _, ok := params["bucket_name"]; !ok {
+				return errors.New("bucket_name is required for S3")
+			}
+			return nil
+		},
+		New: nativeDataStoreFactory("S3"),
+	})
+
+	RegisterDataStore("FS", DataStoreFactory{
+		Validate: func(params map[string]string) error {
+			if _, ok := params["base_path"]; !ok {
+				return errors.New("base_path is required for FS")
+			}
+			return nil
+		},
+		New: nativeDataStoreFactory("FS"),
+	})
+}
+
+// nativeDataStoreFactory builds a DataStoreFactory.New func that delegates
+// to the stellar-datastore package's own constructor, for the backend types
+// it already implements natively.
+func nativeDataStoreFactory(dsType string) func(ctx context.Context, params map[string]string, schema datastore.DataStoreSchema) (datastore.DataStore, error) {
+	return func(ctx context.Context, params map[string]string, schema datastore.DataStoreSchema) (datastore.DataStore, error) {
+		return datastore.NewDataStore(ctx, datastore.DataStoreConfig{
+			Type:   dsType,
+			Params: params,
+			Schema: schema,
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package galexie
+
+import (
+	"context"
+	"testing"
+
+	datastore "github.com/withObsrvr/stellar-datastore"
+)
+
+func testDataStoreFactory() DataStoreFactory {
+	return DataStoreFactory{
+		Validate: func(params map[string]string) error { return nil },
+		New: func(ctx context.Context, params map[string]string, schema datastore.DataStoreSchema) (datastore.DataStore, error) {
+			return nil, nil
+		},
+	}
+}
+
+func TestRegisterDataStoreDuplicate(t *testing.T) {
+	const name = "test-duplicate-backend"
+
+	RegisterDataStore(name, testDataStoreFactory())
+	t.Cleanup(func() {
+		dataStoreRegistryMu.Lock()
+		delete(dataStoreRegistry, name)
+		dataStoreRegistryMu.Unlock()
+	})
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Fatal("expected RegisterDataStore to panic on duplicate registration")
+		}
+	}()
+	RegisterDataStore(name, testDataStoreFactory())
+}
+
+func TestLookupDataStoreUnknownType(t *testing.T) {
+	if _, err := lookupDataStore("does-not-exist"); err == nil {
+		t.Fatal("expected an error for an unregistered datastore type")
+	}
+}
+
+func TestLookupDataStoreRegistered(t *testing.T) {
+	const name = "test-registered-backend"
+
+	RegisterDataStore(name, testDataStoreFactory())
+	t.Cleanup(func() {
+		dataStoreRegistryMu.Lock()
+		delete(dataStoreRegistry, name)
+		dataStoreRegistryMu.Unlock()
+	})
+
+	if _, err := lookupDataStore(name); err != nil {
+		t.Fatalf("unexpected error looking up a registered backend: %v", err)
+	}
+}
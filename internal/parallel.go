@@ -0,0 +1,130 @@
+package galexie
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/stellar/go/support/errors"
+	ledgerbackend "github.com/withObsrvr/stellar-ledgerbackend"
+)
+
+// WorkerScanFillFunc exports a single bounded ledger range using a
+// dedicated captive-core instance. It is invoked once per sub-range handed
+// out by RunParallelScanFill.
+type WorkerScanFillFunc func(ctx context.Context, workerIndex int, ledgerRange LedgerRange, coreConfig ledgerbackend.CaptiveCoreConfig) error
+
+// RunParallelScanFill shards the configured [StartLedger, EndLedger] range
+// into contiguous, file-boundary-aligned sub-ranges (see ComputeJobRanges)
+// and distributes them across ParallelSettings.WorkerCount workers, each
+// backed by its own captive-core instance and storage path so that SQLite
+// state files don't contend with one another. The first worker error
+// cancels the remaining workers; once all workers finish successfully, a
+// gap-scan confirms the datastore has no missing files across the full
+// range.
+func (config *Config) RunParallelScanFill(ctx context.Context, coreBinFromPath string, run WorkerScanFillFunc) error {
+	ranges, err := config.ComputeJobRanges()
+	if err != nil {
+		return err
+	}
+
+	workerCount := int(config.ParallelSettings.WorkerCount)
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	jobs := make(chan LedgerRange)
+	errs := make(chan error, workerCount)
+	var wg sync.WaitGroup
+
+	for i := 0; i < workerCount; i++ {
+		workerIndex := i
+		coreConfig, err := config.generateWorkerCaptiveCoreConfig(coreBinFromPath, workerIndex)
+		if err != nil {
+			return fmt.Errorf("failed to generate captive-core config for worker %d: %w", workerIndex, err)
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case ledgerRange, ok := <-jobs:
+					if !ok {
+						return
+					}
+					logger.Infof("worker %d: exporting ledgers %d-%d", workerIndex, ledgerRange.StartLedger, ledgerRange.EndLedger)
+					if err := run(ctx, workerIndex, ledgerRange, coreConfig); err != nil {
+						errs <- fmt.Errorf("worker %d failed on range %d-%d: %w",
+							workerIndex, ledgerRange.StartLedger, ledgerRange.EndLedger, err)
+						cancel()
+						return
+					}
+					logger.Infof("worker %d: finished ledgers %d-%d", workerIndex, ledgerRange.StartLedger, ledgerRange.EndLedger)
+				}
+			}
+		}()
+	}
+
+feed:
+	for _, r := range ranges {
+		select {
+		case jobs <- r:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(jobs)
+
+	wg.Wait()
+	close(errs)
+
+	if err := <-errs; err != nil {
+		return err
+	}
+
+	return config.gapScan(ctx)
+}
+
+// generateWorkerCaptiveCoreConfig builds a captive-core config for the given
+// worker, suffixing the configured storage path with the worker index so
+// that concurrent captive-core processes never contend over the same
+// SQLite-backed storage directory.
+func (config *Config) generateWorkerCaptiveCoreConfig(coreBinFromPath string, workerIndex int) (ledgerbackend.CaptiveCoreConfig, error) {
+	coreConfig, err := config.GenerateCaptiveCoreConfig(coreBinFromPath)
+	if err != nil {
+		return ledgerbackend.CaptiveCoreConfig{}, err
+	}
+	if coreConfig.StoragePath != "" {
+		coreConfig.StoragePath = fmt.Sprintf("%s-worker%d", coreConfig.StoragePath, workerIndex)
+	}
+	return coreConfig, nil
+}
+
+// gapScan re-walks the full configured ledger range and confirms that the
+// datastore has an export file covering every expected boundary, catching
+// any sub-range a worker may have skipped after an unreported failure.
+func (config *Config) gapScan(ctx context.Context) error {
+	dataStore, err := config.DataStoreConfig.GetDataStore(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to open datastore for gap-scan")
+	}
+	defer dataStore.Close()
+
+	schema := config.DataStoreConfig.Schema
+	for ledger := config.StartLedger; ledger <= config.EndLedger; {
+		objectKey := schema.GetObjectKeyFromSequenceNumber(ledger)
+		exists, err := dataStore.Exists(ctx, objectKey)
+		if err != nil {
+			return errors.Wrapf(err, "gap-scan failed checking %s", objectKey)
+		}
+		if !exists {
+			return errors.Errorf("gap-scan detected missing export file %s for ledger %d", objectKey, ledger)
+		}
+		ledger = schema.GetSequenceNumberEndBoundary(ledger) + 1
+	}
+
+	logger.Infof("gap-scan confirmed no missing files across %d-%d", config.StartLedger, config.EndLedger)
+	return nil
+}
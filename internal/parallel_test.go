@@ -0,0 +1,71 @@
+package galexie
+
+import "testing"
+
+func TestRoundUpToMultiple(t *testing.T) {
+	cases := []struct {
+		name  string
+		value uint32
+		step  uint32
+		want  uint32
+	}{
+		{name: "already a multiple", value: 100, step: 50, want: 100},
+		{name: "rounds up to next multiple", value: 60, step: 50, want: 100},
+		{name: "step of zero is a no-op", value: 60, step: 0, want: 60},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := roundUpToMultiple(tc.value, tc.step); got != tc.want {
+				t.Fatalf("roundUpToMultiple(%d, %d) = %d, want %d", tc.value, tc.step, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestSplitRangeIntoJobs(t *testing.T) {
+	cases := []struct {
+		name       string
+		start, end uint32
+		jobSize    uint32
+		want       []LedgerRange
+	}{
+		{
+			name:  "range exactly a multiple of job size",
+			start: 2, end: 201, jobSize: 100,
+			want: []LedgerRange{
+				{StartLedger: 2, EndLedger: 101},
+				{StartLedger: 102, EndLedger: 201},
+			},
+		},
+		{
+			name:  "range shorter than a single job produces one truncated range",
+			start: 2, end: 30, jobSize: 100,
+			want: []LedgerRange{
+				{StartLedger: 2, EndLedger: 30},
+			},
+		},
+		{
+			name:  "final job is truncated to the end of the range",
+			start: 2, end: 150, jobSize: 100,
+			want: []LedgerRange{
+				{StartLedger: 2, EndLedger: 101},
+				{StartLedger: 102, EndLedger: 150},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := splitRangeIntoJobs(tc.start, tc.end, tc.jobSize)
+			if len(got) != len(tc.want) {
+				t.Fatalf("got %d ranges, want %d: %+v", len(got), len(tc.want), got)
+			}
+			for i := range got {
+				if got[i] != tc.want[i] {
+					t.Fatalf("range %d: got %+v, want %+v", i, got[i], tc.want[i])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,209 @@
+package galexie
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/stellar/go/historyarchive"
+	"github.com/stellar/go/xdr"
+	datastore "github.com/withObsrvr/stellar-datastore"
+	ledgerbackend "github.com/withObsrvr/stellar-ledgerbackend"
+)
+
+// Run dispatches the three export modes introduced alongside this
+// dispatcher: ParallelScanFill, Verify, and unbounded (tailing) Append.
+// ScanFill and bounded Append are handled by the existing immediate-mode
+// runner and are never passed to Run.
+func (config *Config) Run(ctx context.Context, archive historyarchive.ArchiveInterface, coreBinFromPath string) error {
+	switch config.Mode {
+	case ParallelScanFill:
+		return config.RunParallelScanFill(ctx, coreBinFromPath, config.exportRangeWithCaptiveCore)
+
+	case Verify:
+		return config.runVerify(ctx, coreBinFromPath)
+
+	case Append:
+		if config.EndLedger == 0 {
+			lastExportedLedger := config.StartLedger - 1
+			return config.RunAppendTail(ctx, archive, lastExportedLedger,
+				config.newCaptiveCoreTailSource(coreBinFromPath), config.flushTailedLedgers)
+		}
+	}
+
+	return fmt.Errorf("export mode %q is not handled by this dispatcher; "+
+		"ScanFill and bounded Append run through the existing immediate-mode runner", config.Mode.Name())
+}
+
+// captiveCoreTailSource adapts a captive-core backend to the
+// LedgerCloseMetaSource interface consumed by RunAppendTail.
+type captiveCoreTailSource struct {
+	backend *ledgerbackend.CaptiveStellarCore
+}
+
+func (s *captiveCoreTailSource) GetLedger(ctx context.Context, sequence uint32) (xdr.LedgerCloseMeta, error) {
+	return s.backend.GetLedger(ctx, sequence)
+}
+
+func (s *captiveCoreTailSource) Close() error {
+	return s.backend.Close()
+}
+
+// newCaptiveCoreTailSource returns the constructor RunAppendTail uses to
+// (re)start captive-core from a given ledger, including after a crash.
+func (config *Config) newCaptiveCoreTailSource(coreBinFromPath string) func(ctx context.Context, fromLedger uint32) (LedgerCloseMetaSource, error) {
+	return func(ctx context.Context, fromLedger uint32) (LedgerCloseMetaSource, error) {
+		coreConfig, err := config.GenerateCaptiveCoreConfig(coreBinFromPath)
+		if err != nil {
+			return nil, fmt.Errorf("tail: failed to generate captive-core config: %w", err)
+		}
+
+		backend, err := ledgerbackend.NewCaptive(coreConfig)
+		if err != nil {
+			return nil, fmt.Errorf("tail: failed to start captive-core: %w", err)
+		}
+
+		if err := backend.PrepareRange(ctx, ledgerbackend.UnboundedRange(fromLedger)); err != nil {
+			backend.Close()
+			return nil, fmt.Errorf("tail: failed to prepare unbounded range from %d: %w", fromLedger, err)
+		}
+
+		return &captiveCoreTailSource{backend: backend}, nil
+	}
+}
+
+// flushTailedLedgers is the TailFlushFunc used by RunAppendTail: it
+// XDR-encodes the in-progress batch and uploads it under the object key for
+// its first ledger.
+func (config *Config) flushTailedLedgers(ctx context.Context, ledgers []xdr.LedgerCloseMeta, forceFlush bool) error {
+	if len(ledgers) == 0 {
+		return nil
+	}
+
+	dataStore, err := config.DataStoreConfig.GetDataStore(ctx)
+	if err != nil {
+		return fmt.Errorf("tail: failed to open datastore: %w", err)
+	}
+	defer dataStore.Close()
+
+	start := ledgers[0].LedgerSequence()
+	end := ledgers[len(ledgers)-1].LedgerSequence()
+	objectKey := config.DataStoreConfig.Schema.GetObjectKeyFromSequenceNumber(start)
+
+	if forceFlush {
+		logger.Infof("tail: flushing partial partition %s early (ledgers %d-%d) ahead of the file boundary", objectKey, start, end)
+	}
+
+	return writeLedgerCloseMetaBatch(ctx, dataStore, objectKey, start, end, ledgers)
+}
+
+// runVerify runs RunVerify, comparing against ledgers freshly captured from
+// a dedicated captive-core instance, prints the resulting report, and turns
+// a non-clean report into an error so the process exits non-zero.
+func (config *Config) runVerify(ctx context.Context, coreBinFromPath string) error {
+	backend, err := config.newVerifyCaptiveCore(coreBinFromPath)
+	if err != nil {
+		return err
+	}
+	defer backend.Close()
+
+	if err := backend.PrepareRange(ctx, ledgerbackend.BoundedRange(config.StartLedger, config.EndLedger)); err != nil {
+		return fmt.Errorf("verify: failed to prepare range %d-%d: %w", config.StartLedger, config.EndLedger, err)
+	}
+
+	report, err := config.RunVerify(ctx, func(ctx context.Context, ledger uint32) (xdr.LedgerCloseMeta, error) {
+		return backend.GetLedger(ctx, ledger)
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := report.Print(); err != nil {
+		return fmt.Errorf("verify: failed to print report: %w", err)
+	}
+
+	if !report.Clean() {
+		return fmt.Errorf("verify found %d mismatch(es) and %d missing ledger(s)", len(report.Mismatches), len(report.MissingFiles))
+	}
+	return nil
+}
+
+// newVerifyCaptiveCore starts the captive-core instance used to produce the
+// "fresh" ledgers that exported files are compared against during Verify.
+func (config *Config) newVerifyCaptiveCore(coreBinFromPath string) (*ledgerbackend.CaptiveStellarCore, error) {
+	coreConfig, err := config.GenerateCaptiveCoreConfig(coreBinFromPath)
+	if err != nil {
+		return nil, fmt.Errorf("verify: failed to generate captive-core config: %w", err)
+	}
+
+	backend, err := ledgerbackend.NewCaptive(coreConfig)
+	if err != nil {
+		return nil, fmt.Errorf("verify: failed to start captive-core: %w", err)
+	}
+	return backend, nil
+}
+
+// exportRangeWithCaptiveCore is the WorkerScanFillFunc used by
+// RunParallelScanFill: it prepares the given ledger range on a dedicated
+// captive-core instance and writes one datastore file per LedgersPerFile
+// boundary within that range.
+func (config *Config) exportRangeWithCaptiveCore(ctx context.Context, workerIndex int, ledgerRange LedgerRange, coreConfig ledgerbackend.CaptiveCoreConfig) error {
+	backend, err := ledgerbackend.NewCaptive(coreConfig)
+	if err != nil {
+		return fmt.Errorf("worker %d: failed to start captive-core: %w", workerIndex, err)
+	}
+	defer backend.Close()
+
+	if err := backend.PrepareRange(ctx, ledgerbackend.BoundedRange(ledgerRange.StartLedger, ledgerRange.EndLedger)); err != nil {
+		return fmt.Errorf("worker %d: failed to prepare range %d-%d: %w",
+			workerIndex, ledgerRange.StartLedger, ledgerRange.EndLedger, err)
+	}
+
+	dataStore, err := config.DataStoreConfig.GetDataStore(ctx)
+	if err != nil {
+		return fmt.Errorf("worker %d: failed to open datastore: %w", workerIndex, err)
+	}
+	defer dataStore.Close()
+
+	schema := config.DataStoreConfig.Schema
+	for fileStart := ledgerRange.StartLedger; fileStart <= ledgerRange.EndLedger; fileStart = schema.GetSequenceNumberEndBoundary(fileStart) + 1 {
+		fileEnd := schema.GetSequenceNumberEndBoundary(fileStart)
+		if fileEnd > ledgerRange.EndLedger {
+			fileEnd = ledgerRange.EndLedger
+		}
+
+		var metas []xdr.LedgerCloseMeta
+		for ledger := fileStart; ledger <= fileEnd; ledger++ {
+			meta, err := backend.GetLedger(ctx, ledger)
+			if err != nil {
+				return fmt.Errorf("worker %d: failed to fetch ledger %d: %w", workerIndex, ledger, err)
+			}
+			metas = append(metas, meta)
+		}
+
+		objectKey := schema.GetObjectKeyFromSequenceNumber(fileStart)
+		if err := writeLedgerCloseMetaBatch(ctx, dataStore, objectKey, fileStart, fileEnd, metas); err != nil {
+			return fmt.Errorf("worker %d: failed to write %s: %w", workerIndex, objectKey, err)
+		}
+		logger.Infof("worker %d: wrote %s (ledgers %d-%d)", workerIndex, objectKey, fileStart, fileEnd)
+	}
+
+	return nil
+}
+
+// writeLedgerCloseMetaBatch XDR-encodes a contiguous batch of ledgers and
+// uploads it to the datastore under objectKey.
+func writeLedgerCloseMetaBatch(ctx context.Context, dataStore datastore.DataStore, objectKey string, start, end uint32, metas []xdr.LedgerCloseMeta) error {
+	batch := xdr.LedgerCloseMetaBatch{
+		StartSequence:    xdr.Uint32(start),
+		EndSequence:      xdr.Uint32(end),
+		LedgerCloseMetas: metas,
+	}
+
+	encoded, err := batch.MarshalBinary()
+	if err != nil {
+		return fmt.Errorf("failed to encode ledgers %d-%d: %w", start, end, err)
+	}
+
+	return dataStore.PutFile(ctx, objectKey, bytes.NewReader(encoded))
+}
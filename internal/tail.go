@@ -0,0 +1,140 @@
+package galexie
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/stellar/go/historyarchive"
+	"github.com/stellar/go/support/errors"
+	"github.com/stellar/go/xdr"
+)
+
+const (
+	defaultTailPollInterval     = 6 * time.Second
+	defaultTailMaxFlushInterval = 5 * time.Minute
+)
+
+// TailPollIntervalDuration parses TailPollInterval, defaulting to
+// defaultTailPollInterval when unset.
+func (c *StellarCoreConfig) TailPollIntervalDuration() (time.Duration, error) {
+	if c.TailPollInterval == "" {
+		return defaultTailPollInterval, nil
+	}
+	return time.ParseDuration(c.TailPollInterval)
+}
+
+// TailMaxFlushIntervalDuration parses TailMaxFlushInterval, defaulting to
+// defaultTailMaxFlushInterval when unset.
+func (c *StellarCoreConfig) TailMaxFlushIntervalDuration() (time.Duration, error) {
+	if c.TailMaxFlushInterval == "" {
+		return defaultTailMaxFlushInterval, nil
+	}
+	return time.ParseDuration(c.TailMaxFlushInterval)
+}
+
+// LedgerCloseMetaSource supplies sequentially closed ledgers from a running
+// captive-core subprocess. RunAppendTail restarts a new source whenever the
+// current one stops producing ledgers.
+type LedgerCloseMetaSource interface {
+	GetLedger(ctx context.Context, sequence uint32) (xdr.LedgerCloseMeta, error)
+	Close() error
+}
+
+// TailFlushFunc persists a batch of freshly captured ledgers. forceFlush is
+// true when the batch is being flushed early because max_flush_interval
+// elapsed rather than because a file boundary was reached.
+type TailFlushFunc func(ctx context.Context, ledgers []xdr.LedgerCloseMeta, forceFlush bool) error
+
+// RunAppendTail formalizes unbounded Append mode: once the export has
+// caught up to the network, it periodically re-polls the history archive's
+// latest checkpoint and keeps asking captive-core for newly closed ledgers,
+// flushing the in-progress partition at a file boundary or after
+// tail_max_flush_interval elapses, whichever happens first. If the
+// captive-core subprocess falls behind or crashes, newSource is invoked
+// again to restart it from the first ledger not yet captured, keeping the
+// ledgers already pending for the in-progress partition.
+func (config *Config) RunAppendTail(
+	ctx context.Context,
+	archive historyarchive.ArchiveInterface,
+	lastExportedLedger uint32,
+	newSource func(ctx context.Context, fromLedger uint32) (LedgerCloseMetaSource, error),
+	flush TailFlushFunc,
+) error {
+	pollInterval, err := config.StellarCoreConfig.TailPollIntervalDuration()
+	if err != nil {
+		return errors.Wrap(err, "failed to parse stellar_core_config.tail_poll_interval")
+	}
+	maxFlushInterval, err := config.StellarCoreConfig.TailMaxFlushIntervalDuration()
+	if err != nil {
+		return errors.Wrap(err, "failed to parse stellar_core_config.tail_max_flush_interval")
+	}
+
+	nextLedger := lastExportedLedger + 1
+	source, err := newSource(ctx, nextLedger)
+	if err != nil {
+		return fmt.Errorf("failed to start captive-core for tail: %w", err)
+	}
+	defer func() { source.Close() }()
+
+	var pending []xdr.LedgerCloseMeta
+	lastFlush := time.Now()
+
+	// latestNetworkLedger is refreshed at most once per pollInterval (rather
+	// than once per loop iteration/ledger) so that a tail job starting far
+	// behind the network doesn't hammer the history archive with one HTTP
+	// request per ledger while it's catching up.
+	var latestNetworkLedger uint32
+	var lastHASFetch time.Time
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+
+		if nextLedger > latestNetworkLedger || time.Since(lastHASFetch) >= pollInterval {
+			has, err := archive.GetRootHAS()
+			if err != nil {
+				return errors.Wrap(err, "failed to poll history archive for latest checkpoint")
+			}
+			latestNetworkLedger = has.CurrentLedger
+			lastHASFetch = time.Now()
+		}
+
+		meta, err := source.GetLedger(ctx, nextLedger)
+		if err != nil {
+			logger.Warnf("tail: captive-core failed to produce ledger %d, restarting from there: %v", nextLedger, err)
+			source.Close()
+			if source, err = newSource(ctx, nextLedger); err != nil {
+				return fmt.Errorf("failed to restart captive-core for tail: %w", err)
+			}
+			time.Sleep(pollInterval)
+			continue
+		}
+
+		pending = append(pending, meta)
+		nextLedger = meta.LedgerSequence() + 1
+
+		atFileBoundary := config.DataStoreConfig.Schema.GetSequenceNumberEndBoundary(meta.LedgerSequence()) == meta.LedgerSequence()
+		pastFlushDeadline := time.Since(lastFlush) >= maxFlushInterval
+		if atFileBoundary || pastFlushDeadline {
+			if err := flush(ctx, pending, !atFileBoundary); err != nil {
+				return fmt.Errorf("failed to flush tailed ledgers up to %d: %w", meta.LedgerSequence(), err)
+			}
+			// An early flush keeps the pending ledgers, so the next flush of
+			// the same file rewrites the whole partition rather than only the
+			// ledgers captured since the early flush.
+			if atFileBoundary {
+				pending = nil
+			}
+			lastFlush = time.Now()
+		}
+
+		if nextLedger > latestNetworkLedger {
+			logger.Infof("tail: caught up to network ledger %d, polling again in %s", latestNetworkLedger, pollInterval)
+			time.Sleep(pollInterval)
+		}
+	}
+}